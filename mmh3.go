@@ -6,6 +6,8 @@ package mmh3
 
 import (
 	"encoding/binary"
+	"errors"
+	"io"
 	"math/bits"
 )
 
@@ -22,24 +24,43 @@ func Hash32(data []byte) uint32 {
 	return hash32(data, 0)
 }
 
+// Hash32WithSeed returns the 32-bit MurmurHash3 (x86_32) digest of data
+// for the given seed. Different seeds produce independent hash families
+// from the same input.
+func Hash32WithSeed(data []byte, seed uint32) uint32 {
+	return hash32(data, seed)
+}
+
 func hash32(data []byte, seed uint32) uint32 {
 	h1 := seed
 
 	nblocks := len(data) / 4
 	for i := 0; i < nblocks; i++ {
-		k1 := binary.LittleEndian.Uint32(data[i*4:])
+		h1 = mixBlock32(h1, binary.LittleEndian.Uint32(data[i*4:]))
+	}
 
-		k1 *= c1_32
-		k1 = bits.RotateLeft32(k1, 15)
-		k1 *= c2_32
+	h1 = mixTail32(h1, data[nblocks*4:])
 
-		h1 ^= k1
-		h1 = bits.RotateLeft32(h1, 13)
-		h1 = h1*5 + 0xe6546b64
-	}
+	return finalize32(h1, uint32(len(data)))
+}
+
+func mixBlock32(h1, k1 uint32) uint32 {
+	k1 *= c1_32
+	k1 = bits.RotateLeft32(k1, 15)
+	k1 *= c2_32
 
+	h1 ^= k1
+	h1 = bits.RotateLeft32(h1, 13)
+	h1 = h1*5 + 0xe6546b64
+
+	return h1
+}
+
+// mixTail32 folds a final, less-than-a-block tail (0-3 bytes) into h1.
+func mixTail32(h1 uint32, tail []byte) uint32 {
 	var k1 uint32
-	switch tail := data[nblocks*4:]; len(tail) {
+
+	switch len(tail) {
 	case 3:
 		k1 ^= uint32(tail[2]) << 16
 		fallthrough
@@ -54,12 +75,14 @@ func hash32(data []byte, seed uint32) uint32 {
 		h1 ^= k1
 	}
 
-	h1 ^= uint32(len(data))
-	h1 = fmix32(h1)
-
 	return h1
 }
 
+func finalize32(h1, length uint32) uint32 {
+	h1 ^= length
+	return fmix32(h1)
+}
+
 func fmix32(h uint32) uint32 {
 	h ^= h >> 16
 	h *= 0x85ebca6b
@@ -69,6 +92,12 @@ func fmix32(h uint32) uint32 {
 	return h
 }
 
+func appendUint64(b []byte, v uint64) []byte {
+	var tmp [8]byte
+	binary.BigEndian.PutUint64(tmp[:], v)
+	return append(b, tmp[:]...)
+}
+
 func fmix64(k uint64) uint64 {
 	k ^= k >> 33
 	k *= 0xff51afd7ed558ccd
@@ -85,7 +114,10 @@ type Hash128Value struct {
 }
 
 // Values returns the raw 64-bit words of the digest, in the order in
-// which Bytes and Write serialize them.
+// which Bytes and Write serialize them. This is the source of truth for
+// the digest: Hash64 and HashWriter64 derive their result from the first
+// word returned here, so the 64-bit and 128-bit APIs are guaranteed to
+// agree.
 func (h Hash128Value) Values() (uint64, uint64) {
 	return h.h1, h.h2
 }
@@ -106,7 +138,14 @@ func (h Hash128Value) Write(p []byte) {
 
 // Hash128 returns the 128-bit MurmurHash3 (x64_128) digest of data.
 func Hash128(data []byte) Hash128Value {
-	h1, h2 := hash128x64(data, 0)
+	return Hash128WithSeed(data, 0)
+}
+
+// Hash128WithSeed returns the 128-bit MurmurHash3 (x64_128) digest of
+// data for the given seed. Different seeds produce independent hash
+// families from the same input.
+func Hash128WithSeed(data []byte, seed uint32) Hash128Value {
+	h1, h2 := hash128x64(data, seed)
 	return Hash128Value{h1: h1, h2: h2}
 }
 
@@ -116,6 +155,11 @@ func Hash128x64(data []byte) []byte {
 	return Hash128(data).Bytes()
 }
 
+// Hash128x64WithSeed is like Hash128x64 but for the given seed.
+func Hash128x64WithSeed(data []byte, seed uint32) []byte {
+	return Hash128WithSeed(data, seed).Bytes()
+}
+
 // WriteHash128x64 writes the 16-byte little-endian encoding of the
 // 128-bit MurmurHash3 (x64_128) digest of data into out, which must have
 // a length of at least 16.
@@ -123,6 +167,26 @@ func WriteHash128x64(data []byte, out []byte) {
 	Hash128(data).Write(out)
 }
 
+// WriteHash128x64WithSeed is like WriteHash128x64 but for the given seed.
+func WriteHash128x64WithSeed(data []byte, seed uint32, out []byte) {
+	Hash128WithSeed(data, seed).Write(out)
+}
+
+// Hash64 returns the low 64 bits of the 128-bit MurmurHash3 (x64_128)
+// digest of data, i.e. the first word returned by Hash128(data).Values().
+// This avoids computing or allocating the full 128-bit result for
+// callers, such as Bloom filters and sketches, that only need 64 bits.
+func Hash64(data []byte) uint64 {
+	h1, _ := hash128x64(data, 0)
+	return h1
+}
+
+// Hash64WithSeed is like Hash64 but for the given seed.
+func Hash64WithSeed(data []byte, seed uint32) uint64 {
+	h1, _ := hash128x64(data, seed)
+	return h1
+}
+
 func hash128x64(data []byte, seed uint32) (uint64, uint64) {
 	h1 := uint64(seed)
 	h2 := uint64(seed)
@@ -298,6 +362,22 @@ func (hw *HashWriter128) Reset() {
 	hw.length = 0
 }
 
+// SetSeed resets the writer and seeds it with seed, so that subsequent
+// writes compute the digest for that seed.
+func (hw *HashWriter128) SetSeed(seed uint32) {
+	hw.h1 = uint64(seed)
+	hw.h2 = uint64(seed)
+	hw.buflen = 0
+	hw.length = 0
+}
+
+// NewHashWriter128Seeded returns a HashWriter128 seeded with seed.
+func NewHashWriter128Seeded(seed uint32) *HashWriter128 {
+	hw := &HashWriter128{}
+	hw.SetSeed(seed)
+	return hw
+}
+
 // Sum128 returns the current 128-bit digest without mutating the writer,
 // so that writing can continue afterward.
 func (hw *HashWriter128) Sum128() Hash128Value {
@@ -312,3 +392,275 @@ func (hw *HashWriter128) Sum(b []byte) []byte {
 	v := hw.Sum128()
 	return append(b, v.Bytes()...)
 }
+
+// Size returns the number of bytes Sum will append: 16.
+func (hw *HashWriter128) Size() int { return 16 }
+
+// BlockSize returns the writer's internal block size: 16.
+func (hw *HashWriter128) BlockSize() int { return 16 }
+
+const magic128 = "mmh3\x01"
+
+// MarshalBinary implements encoding.BinaryMarshaler, allowing a
+// HashWriter128's state to be checkpointed and later restored with
+// UnmarshalBinary, including across process boundaries, the way
+// crypto/sha256 supports resuming a hash from a saved checksum state.
+func (hw *HashWriter128) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, len(magic128)+8+8+8+1+len(hw.buf))
+	b = append(b, magic128...)
+	b = appendUint64(b, hw.h1)
+	b = appendUint64(b, hw.h2)
+	b = appendUint64(b, hw.length)
+	b = append(b, byte(hw.buflen))
+	b = append(b, hw.buf[:hw.buflen]...)
+
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (hw *HashWriter128) UnmarshalBinary(b []byte) error {
+	if len(b) < len(magic128) || string(b[:len(magic128)]) != magic128 {
+		return errors.New("mmh3: invalid hash state identifier")
+	}
+	b = b[len(magic128):]
+
+	if len(b) < 8+8+8+1 {
+		return errors.New("mmh3: invalid hash state size")
+	}
+
+	hw.h1 = binary.BigEndian.Uint64(b[0:8])
+	hw.h2 = binary.BigEndian.Uint64(b[8:16])
+	hw.length = binary.BigEndian.Uint64(b[16:24])
+	b = b[24:]
+
+	buflen := int(b[0])
+	b = b[1:]
+
+	if buflen > len(hw.buf) || len(b) < buflen {
+		return errors.New("mmh3: invalid hash state size")
+	}
+
+	hw.buflen = buflen
+	copy(hw.buf[:], b[:buflen])
+
+	return nil
+}
+
+// HashWriter64 incrementally computes the low 64 bits of the 128-bit
+// MurmurHash3 (x64_128) digest. It wraps a HashWriter128 and reuses its
+// x64 state and finalizer, so Hash64 and HashWriter64 always agree with
+// Hash128 and HashWriter128. The zero value is ready to use with seed 0.
+type HashWriter64 struct {
+	hw HashWriter128
+}
+
+// Write implements io.Writer, feeding p into the running hash.
+func (hw *HashWriter64) Write(p []byte) (int, error) {
+	return hw.hw.Write(p)
+}
+
+// WriteString is like Write but avoids allocating a []byte copy of s.
+func (hw *HashWriter64) WriteString(s string) (int, error) {
+	return hw.hw.WriteString(s)
+}
+
+// Reset restores the writer to its initial state with seed 0.
+func (hw *HashWriter64) Reset() {
+	hw.hw.Reset()
+}
+
+// Sum64 returns the low 64 bits of the current digest.
+func (hw *HashWriter64) Sum64() uint64 {
+	h1, _ := hw.hw.Sum128().Values()
+	return h1
+}
+
+// Sum appends the big-endian encoding of the current 64-bit digest to b
+// and returns the resulting slice, as required by hash.Hash.
+func (hw *HashWriter64) Sum(b []byte) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], hw.Sum64())
+	return append(b, buf[:]...)
+}
+
+// Size returns the number of bytes Sum will append: 8.
+func (hw *HashWriter64) Size() int { return 8 }
+
+// BlockSize returns the writer's internal block size: 16.
+func (hw *HashWriter64) BlockSize() int { return 16 }
+
+// HashWriter32 incrementally computes the 32-bit MurmurHash3 (x86_32)
+// digest of the data written to it. The zero value is ready to use with
+// seed 0. It satisfies hash.Hash32.
+type HashWriter32 struct {
+	h1     uint32
+	buf    [4]byte
+	buflen int
+	length uint32
+}
+
+// Write implements io.Writer, feeding p into the running hash.
+func (hw *HashWriter32) Write(p []byte) (int, error) {
+	hw.AddBytes(p)
+	return len(p), nil
+}
+
+// WriteString is like Write but avoids allocating a []byte copy of s.
+func (hw *HashWriter32) WriteString(s string) (int, error) {
+	return hw.Write([]byte(s))
+}
+
+// AddBytes feeds data into the running hash. It behaves like Write but
+// without the io.Writer signature, for callers that don't need it.
+func (hw *HashWriter32) AddBytes(data []byte) {
+	hw.length += uint32(len(data))
+
+	if hw.buflen > 0 {
+		n := copy(hw.buf[hw.buflen:], data)
+		hw.buflen += n
+		data = data[n:]
+
+		if hw.buflen < len(hw.buf) {
+			return
+		}
+
+		hw.h1 = mixBlock32(hw.h1, binary.LittleEndian.Uint32(hw.buf[:]))
+		hw.buflen = 0
+	}
+
+	for len(data) >= 4 {
+		hw.h1 = mixBlock32(hw.h1, binary.LittleEndian.Uint32(data))
+		data = data[4:]
+	}
+
+	hw.buflen = copy(hw.buf[:], data)
+}
+
+// Reset restores the writer to its initial state with seed 0.
+func (hw *HashWriter32) Reset() {
+	hw.h1 = 0
+	hw.buflen = 0
+	hw.length = 0
+}
+
+// SetSeed resets the writer and seeds it with seed, so that subsequent
+// writes compute the digest for that seed.
+func (hw *HashWriter32) SetSeed(seed uint32) {
+	hw.h1 = seed
+	hw.buflen = 0
+	hw.length = 0
+}
+
+// NewHashWriter32Seeded returns a HashWriter32 seeded with seed.
+func NewHashWriter32Seeded(seed uint32) *HashWriter32 {
+	hw := &HashWriter32{}
+	hw.SetSeed(seed)
+	return hw
+}
+
+// Sum32 returns the current 32-bit digest without mutating the writer,
+// so that writing can continue afterward.
+func (hw *HashWriter32) Sum32() uint32 {
+	h1 := mixTail32(hw.h1, hw.buf[:hw.buflen])
+	return finalize32(h1, hw.length)
+}
+
+// Sum appends the current 32-bit digest, big-endian, to b and returns
+// the resulting slice, as required by hash.Hash.
+func (hw *HashWriter32) Sum(b []byte) []byte {
+	var tmp [4]byte
+	binary.BigEndian.PutUint32(tmp[:], hw.Sum32())
+	return append(b, tmp[:]...)
+}
+
+// Size returns the number of bytes Sum will append: 4.
+func (hw *HashWriter32) Size() int { return 4 }
+
+// BlockSize returns the writer's internal block size: 4.
+func (hw *HashWriter32) BlockSize() int { return 4 }
+
+const magic32 = "mmh3\x02"
+
+// MarshalBinary implements encoding.BinaryMarshaler, allowing a
+// HashWriter32's state to be checkpointed and later restored with
+// UnmarshalBinary, including across process boundaries.
+func (hw *HashWriter32) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, len(magic32)+4+4+1+len(hw.buf))
+	b = append(b, magic32...)
+
+	var tmp [4]byte
+	binary.BigEndian.PutUint32(tmp[:], hw.h1)
+	b = append(b, tmp[:]...)
+	binary.BigEndian.PutUint32(tmp[:], hw.length)
+	b = append(b, tmp[:]...)
+
+	b = append(b, byte(hw.buflen))
+	b = append(b, hw.buf[:hw.buflen]...)
+
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (hw *HashWriter32) UnmarshalBinary(b []byte) error {
+	if len(b) < len(magic32) || string(b[:len(magic32)]) != magic32 {
+		return errors.New("mmh3: invalid hash state identifier")
+	}
+	b = b[len(magic32):]
+
+	if len(b) < 4+4+1 {
+		return errors.New("mmh3: invalid hash state size")
+	}
+
+	hw.h1 = binary.BigEndian.Uint32(b[0:4])
+	hw.length = binary.BigEndian.Uint32(b[4:8])
+	b = b[8:]
+
+	buflen := int(b[0])
+	b = b[1:]
+
+	if buflen > len(hw.buf) || len(b) < buflen {
+		return errors.New("mmh3: invalid hash state size")
+	}
+
+	hw.buflen = buflen
+	copy(hw.buf[:], b[:buflen])
+
+	return nil
+}
+
+// HashReader128 wraps an io.Reader and computes the 128-bit MurmurHash3
+// (x64_128) digest of all bytes read through it, so large files or HTTP
+// bodies can be hashed in a single pass without buffering.
+type HashReader128 struct {
+	r  io.Reader
+	hw HashWriter128
+}
+
+// NewHashReader128 returns a HashReader128 that hashes everything read
+// from r.
+func NewHashReader128(r io.Reader) *HashReader128 {
+	return &HashReader128{r: r}
+}
+
+// Read implements io.Reader, forwarding to the wrapped reader and
+// feeding the bytes read into the running hash.
+func (hr *HashReader128) Read(p []byte) (int, error) {
+	n, err := hr.r.Read(p)
+	hr.hw.AddBytes(p[:n])
+	return n, err
+}
+
+// Sum128 returns the 128-bit digest of all bytes read so far.
+func (hr *HashReader128) Sum128() Hash128Value {
+	return hr.hw.Sum128()
+}
+
+// TeeHash128 returns a reader that forwards everything read from r, and
+// a HashWriter128 that accumulates the 128-bit digest of those bytes as
+// they are read. This mirrors the ergonomics of crypto/sha256.New
+// composed with io.TeeReader, without requiring callers to wire up the
+// TeeReader themselves.
+func TeeHash128(r io.Reader) (io.Reader, *HashWriter128) {
+	hw := &HashWriter128{}
+	return io.TeeReader(r, hw), hw
+}
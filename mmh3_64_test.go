@@ -0,0 +1,36 @@
+package mmh3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHash64AgreesWithHash128(t *testing.T) {
+	for _, s := range []string{"", "hello", "hello world", "Winter is coming"} {
+		h1, _ := Hash128([]byte(s)).Values()
+		assert.Equal(t, h1, Hash64([]byte(s)), s)
+	}
+}
+
+func TestHash64WithSeedAgreesWithHash128WithSeed(t *testing.T) {
+	for _, seed := range []uint32{0x00, 0x01, 0x2a} {
+		h1, _ := Hash128WithSeed([]byte("hello"), seed).Values()
+		assert.Equal(t, h1, Hash64WithSeed([]byte("hello"), seed))
+	}
+}
+
+func TestHashWriter64(t *testing.T) {
+	s := []byte("hello, world")
+
+	hw := HashWriter64{}
+	_, _ = hw.Write(s)
+
+	assert.Equal(t, Hash64(s), hw.Sum64())
+	assert.Equal(t, 8, hw.Size())
+	assert.Equal(t, 16, hw.BlockSize())
+
+	hw.Reset()
+	_, _ = hw.WriteString("hello")
+	assert.Equal(t, Hash64([]byte("hello")), hw.Sum64())
+}
@@ -0,0 +1,71 @@
+package mmh3
+
+import (
+	"hash"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashWriter128SatisfiesHashHash(t *testing.T) {
+	var _ hash.Hash = &HashWriter128{}
+
+	hw := HashWriter128{}
+	assert.Equal(t, 16, hw.Size())
+	assert.Equal(t, 16, hw.BlockSize())
+}
+
+func TestHashWriter32(t *testing.T) {
+	var _ hash.Hash32 = &HashWriter32{}
+
+	for _, s := range []string{"", "a", "hello", "hello, world", "Winter is coming"} {
+		hw := HashWriter32{}
+		_, _ = hw.Write([]byte(s))
+
+		assert.Equal(t, Hash32([]byte(s)), hw.Sum32(), s)
+	}
+
+	hw := HashWriter32{}
+	assert.Equal(t, 4, hw.Size())
+	assert.Equal(t, 4, hw.BlockSize())
+}
+
+func TestHashWriter32SetSeed(t *testing.T) {
+	hw := HashWriter32{}
+	hw.SetSeed(0x2a)
+	_, _ = hw.Write([]byte("hello"))
+
+	assert.Equal(t, Hash32WithSeed([]byte("hello"), 0x2a), hw.Sum32())
+}
+
+func TestHashWriter128BinaryMarshaling(t *testing.T) {
+	hw := HashWriter128{}
+	_, _ = hw.Write([]byte("hello, "))
+
+	state, err := hw.MarshalBinary()
+	require.NoError(t, err)
+
+	resumed := HashWriter128{}
+	require.NoError(t, resumed.UnmarshalBinary(state))
+	_, _ = resumed.Write([]byte("world"))
+
+	_, _ = hw.Write([]byte("world"))
+
+	assert.Equal(t, hw.Sum128(), resumed.Sum128())
+	assert.Equal(t, Hash128([]byte("hello, world")), resumed.Sum128())
+}
+
+func TestHashWriter32BinaryMarshaling(t *testing.T) {
+	hw := HashWriter32{}
+	_, _ = hw.Write([]byte("hello, "))
+
+	state, err := hw.MarshalBinary()
+	require.NoError(t, err)
+
+	resumed := HashWriter32{}
+	require.NoError(t, resumed.UnmarshalBinary(state))
+	_, _ = resumed.Write([]byte("world"))
+
+	assert.Equal(t, Hash32([]byte("hello, world")), resumed.Sum32())
+}
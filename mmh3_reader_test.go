@@ -0,0 +1,36 @@
+package mmh3
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashReader128(t *testing.T) {
+	s := []byte("The quick brown fox jumps over the lazy dog.")
+
+	hr := NewHashReader128(bytes.NewReader(s))
+
+	out, err := io.ReadAll(hr)
+	require.NoError(t, err)
+	assert.Equal(t, s, out)
+
+	assert.Equal(t, Hash128(s), hr.Sum128())
+}
+
+func TestTeeHash128(t *testing.T) {
+	s := []byte("hello, world")
+
+	r, hw := TeeHash128(bytes.NewReader(s))
+
+	var dst bytes.Buffer
+	n, err := io.Copy(&dst, r)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(s)), n)
+	assert.Equal(t, s, dst.Bytes())
+
+	assert.Equal(t, Hash128(s), hw.Sum128())
+}
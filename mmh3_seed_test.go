@@ -0,0 +1,62 @@
+package mmh3
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectedValuesWithSeed(t *testing.T) {
+	seeds := map[string]uint32{
+		"01": 0x01,
+		"2a": 0x2a,
+	}
+
+	for suffix, seed := range seeds {
+		seed := seed
+
+		t.Run("Hash32/"+suffix, func(t *testing.T) {
+			readExpectedValues(t, "testdata/32/expected_seed_"+suffix+".txt", func(key, value string) {
+				expectedValue, err := strconv.ParseUint(value, 10, 0)
+				require.NoError(t, err)
+
+				assert.Equal(t, uint32(expectedValue), Hash32WithSeed([]byte(key), seed))
+			})
+		})
+
+		t.Run("Hash128/"+suffix, func(t *testing.T) {
+			readExpectedValues(t, "testdata/128/expected_seed_"+suffix+".txt", func(key, value string) {
+				expectedValue, err := hex.DecodeString(value)
+				require.NoError(t, err)
+
+				assert.Equal(t, expectedValue, Hash128x64WithSeed([]byte(key), seed))
+			})
+		})
+
+		t.Run("Hash128Writer/"+suffix, func(t *testing.T) {
+			readExpectedValues(t, "testdata/128/expected_seed_"+suffix+".txt", func(key, value string) {
+				expectedValue, err := hex.DecodeString(value)
+				require.NoError(t, err)
+
+				hw := NewHashWriter128Seeded(seed)
+				_, _ = hw.Write([]byte(key))
+
+				h := make([]byte, 16)
+				hw.Sum(h[:0])
+
+				assert.Equal(t, expectedValue, h)
+			})
+		})
+	}
+}
+
+func TestHashWriter128SetSeed(t *testing.T) {
+	hw := HashWriter128{}
+	hw.SetSeed(0x2a)
+	_, _ = hw.Write([]byte("hello"))
+
+	assert.Equal(t, Hash128WithSeed([]byte("hello"), 0x2a), hw.Sum128())
+}